@@ -0,0 +1,193 @@
+package conventional
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Person identifies a commit trailer author, such as a Co-authored-by.
+type Person struct {
+	Name  string
+	Email string
+}
+
+type ConventionalCommit struct {
+	Type         string
+	SemVerChange SemVerComponent
+	Scope        string
+	Description  string
+	Body         string
+	Bang         bool
+
+	// Footers holds every trailer-style footer found after the body, keyed
+	// by its token (e.g. "Reviewed-by", "BREAKING CHANGE"). Values preserve
+	// the order they appeared in.
+	Footers map[string][]string
+	// Refs lists issue references mined from the body and footers, such as
+	// "#123", "GH-45" or "org/repo#7".
+	Refs []string
+	// CoAuthors lists the people named in Co-authored-by footers.
+	CoAuthors []Person
+	// PullRequest is the PR number trailing the subject in "(#NN)", the
+	// shape GitHub produces for squash-merge commits.
+	PullRequest *int
+}
+
+type Commits []*ConventionalCommit
+
+var conventionalRegexp = regexp.MustCompile(`^([^(:!]+)(?:\(([^)]+)\))?(!)?: ([^\n]+)$`)
+var pullRequestRegexp = regexp.MustCompile(`\(#(\d+)\)\s*$`)
+// footerTokenRegexp splits a trailer line into its token and value. Group 2
+// is non-empty for the "Token: value" separator (Go's RE2 engine has no
+// lookahead, so the "#" of a "Token #NNN" shorthand can't be excluded from
+// the value up front); group 3 is non-empty for the bare-space separator
+// used by that shorthand. isFooterValue below rejects bare-space matches
+// whose value doesn't actually start with "#".
+var footerTokenRegexp = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z0-9][A-Za-z0-9-]*)(?:(:\s?)|(\s))(.*)$`)
+var refRegexp = regexp.MustCompile(`([A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+)?#(\d+)|\bGH-(\d+)\b`)
+var coAuthorRegexp = regexp.MustCompile(`^([^<]+?)\s*<([^>]+)>$`)
+
+func ParseCommit(msg string) *ConventionalCommit {
+	msg = strings.TrimRight(msg, "\n")
+	lines := strings.Split(msg, "\n")
+	header := lines[0]
+
+	if !conventionalRegexp.MatchString(header) {
+		return nil
+	}
+
+	opts := conventionalRegexp.FindStringSubmatch(header)
+	kind, scope, bang, change := opts[1], opts[2], opts[3], opts[4]
+
+	res := &ConventionalCommit{
+		Type:        kind,
+		Scope:       scope,
+		Description: change,
+		Bang:        bang == "!",
+		Footers:     map[string][]string{},
+	}
+
+	if m := pullRequestRegexp.FindStringSubmatch(change); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			res.PullRequest = &n
+		}
+	}
+
+	if len(lines) > 1 {
+		body, footers := splitFooters(strings.TrimSpace(strings.Join(lines[1:], "\n")))
+		res.Body = body
+		res.Footers = footers
+
+		for token, values := range footers {
+			if strings.EqualFold(token, "BREAKING CHANGE") || strings.EqualFold(token, "BREAKING-CHANGE") {
+				res.Bang = true
+			}
+			if strings.EqualFold(token, "Co-authored-by") {
+				for _, v := range values {
+					if m := coAuthorRegexp.FindStringSubmatch(v); m != nil {
+						res.CoAuthors = append(res.CoAuthors, Person{Name: m[1], Email: m[2]})
+					}
+				}
+			}
+		}
+	}
+
+	res.Refs = extractRefs(res.Body)
+	for token, values := range res.Footers {
+		for _, v := range values {
+			res.Refs = append(res.Refs, extractRefs(token+": "+v)...)
+		}
+	}
+	res.Refs = dedupeRefs(res.Refs)
+
+	return res
+}
+
+// splitFooters separates the Conventional Commits footer block (the final
+// paragraph, if every one of its lines is either a "Token: value" /
+// "Token #value" trailer or a continuation of the previous one) from the
+// rest of the commit body.
+func splitFooters(rest string) (body string, footers map[string][]string) {
+	footers = map[string][]string{}
+	if rest == "" {
+		return "", footers
+	}
+
+	paragraphs := strings.Split(rest, "\n\n")
+	candidate := paragraphs[len(paragraphs)-1]
+
+	parsed := map[string][]string{}
+	var lastToken string
+	for _, l := range strings.Split(candidate, "\n") {
+		if l == "" {
+			continue
+		}
+		if m := footerTokenRegexp.FindStringSubmatch(l); m != nil && isFooterValue(m) {
+			lastToken = canonicalFooterToken(m[1])
+			parsed[lastToken] = append(parsed[lastToken], strings.TrimSpace(m[4]))
+			continue
+		}
+		if lastToken == "" {
+			return strings.TrimSpace(rest), footers
+		}
+		idx := len(parsed[lastToken]) - 1
+		parsed[lastToken][idx] += "\n" + l
+	}
+
+	if len(parsed) == 0 {
+		return strings.TrimSpace(rest), footers
+	}
+
+	body = strings.TrimSpace(strings.Join(paragraphs[:len(paragraphs)-1], "\n\n"))
+	return body, parsed
+}
+
+// isFooterValue reports whether a footerTokenRegexp match is a genuine
+// trailer rather than an ordinary sentence that happens to start with a
+// token-shaped word followed by a space. The "Token: value" separator
+// (m[2]) always counts; the bare-space separator (m[3]) only counts when
+// the value is the "#NNN" shorthand, e.g. "Closes #10".
+func isFooterValue(m []string) bool {
+	if m[2] != "" {
+		return true
+	}
+	return strings.HasPrefix(m[4], "#")
+}
+
+func canonicalFooterToken(t string) string {
+	if strings.EqualFold(t, "BREAKING CHANGE") || strings.EqualFold(t, "BREAKING-CHANGE") {
+		return "BREAKING CHANGE"
+	}
+	return t
+}
+
+func extractRefs(text string) []string {
+	var refs []string
+	for _, m := range refRegexp.FindAllStringSubmatch(text, -1) {
+		switch {
+		case m[2] != "":
+			if m[1] != "" {
+				refs = append(refs, m[1]+"#"+m[2])
+			} else {
+				refs = append(refs, "#"+m[2])
+			}
+		case m[3] != "":
+			refs = append(refs, "GH-"+m[3])
+		}
+	}
+	return refs
+}
+
+func dedupeRefs(refs []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, r := range refs {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		out = append(out, r)
+	}
+	return out
+}
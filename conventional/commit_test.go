@@ -0,0 +1,28 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommit_PullRequestNotDuplicatedInRefs(t *testing.T) {
+	c := ParseCommit("fix: x (#42)\n\nCloses #10")
+	require.NotNil(t, c)
+	require.NotNil(t, c.PullRequest)
+	assert.Equal(t, 42, *c.PullRequest)
+	assert.Equal(t, []string{"#10"}, c.Refs)
+}
+
+func TestParseCommit_FootersAndCoAuthors(t *testing.T) {
+	c := ParseCommit("feat(api): add widgets\n\nBREAKING CHANGE: removes old endpoint\nCo-authored-by: Jane Doe <jane@example.com>")
+	require.NotNil(t, c)
+	assert.True(t, c.Bang)
+	assert.Equal(t, []Person{{Name: "Jane Doe", Email: "jane@example.com"}}, c.CoAuthors)
+	assert.Nil(t, c.PullRequest)
+}
+
+func TestParseCommit_NonConventional(t *testing.T) {
+	assert.Nil(t, ParseCommit("not a conventional commit"))
+}
@@ -0,0 +1,102 @@
+// Package conventional implements parsing of Conventional Commits messages
+// and the rules used to translate them into a SemVer bump.
+package conventional
+
+import "strings"
+
+type SemVerComponent int
+
+const (
+	SemVerNone SemVerComponent = iota
+	SemVerPatch
+	SemVerMinor
+	SemVerMajor
+)
+
+var semverString = map[string]SemVerComponent{
+	"patch": SemVerPatch,
+	"minor": SemVerMinor,
+	"major": SemVerMajor,
+}
+
+func semverFromString(n string) SemVerComponent {
+	n = strings.TrimSpace(strings.ToLower(n))
+	if v, ok := semverString[n]; ok {
+		return v
+	}
+
+	return SemVerNone
+}
+
+// String returns the bump's name ("major", "minor", "patch", or "none"),
+// the vocabulary used in machine-readable output. --rules accepts the
+// first three; "none" only ever appears as output.
+func (s SemVerComponent) String() string {
+	switch s {
+	case SemVerMajor:
+		return "major"
+	case SemVerMinor:
+		return "minor"
+	case SemVerPatch:
+		return "patch"
+	default:
+		return "none"
+	}
+}
+
+// DetermineBump inspects commits against rules (in the shape produced by
+// eql.Parse for the --rules flag) and returns the highest SemVer component
+// that should be bumped. The special "bang" rule governs commits marked as
+// breaking, regardless of their type.
+func DetermineBump(rules map[string]string, commits Commits) SemVerComponent {
+	bang := SemVerNone
+	_, hasBang := rules["bang"]
+	components := map[SemVerComponent][]string{}
+	toBump := SemVerNone
+
+	for ruleName, kind := range rules {
+		if ruleName == "bang" {
+			bang = semverFromString(kind)
+			continue
+		}
+
+		k := semverFromString(kind)
+		components[k] = append(components[k], ruleName)
+	}
+
+	comps := []SemVerComponent{SemVerMajor, SemVerMinor, SemVerPatch}
+
+	for _, r := range commits {
+		if toBump == SemVerMajor {
+			break
+		}
+
+		if r.Bang && hasBang {
+			if bang > toBump {
+				toBump = bang
+				continue
+			}
+		}
+
+		prefix := strings.ToLower(r.Type)
+	compLoop:
+		for _, v := range comps {
+			prefixes, ok := components[v]
+			if !ok {
+				continue
+			}
+			if toBump > v {
+				continue
+			}
+
+			for _, pr := range prefixes {
+				if strings.ToLower(pr) == prefix {
+					toBump = v
+					break compLoop
+				}
+			}
+		}
+	}
+
+	return toBump
+}
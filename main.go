@@ -2,25 +2,42 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
-	"github.com/google/go-github/v39/github"
+	"github.com/heyvito/semver-releaser/conventional"
 	"github.com/heyvito/semver-releaser/eql"
+	"github.com/heyvito/semver-releaser/monorepo"
+	"github.com/heyvito/semver-releaser/pkg/releaser"
+	"github.com/heyvito/semver-releaser/provider"
+	"github.com/heyvito/semver-releaser/releasenotes"
+	"github.com/heyvito/semver-releaser/signing"
+	"github.com/heyvito/semver-releaser/version"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/mod/semver"
-	"golang.org/x/oauth2"
-	"io"
 	"os"
-	"regexp"
 	"sort"
-	"strconv"
 	"strings"
+	"time"
 )
 
+const (
+	tagModeAllBranches   = "all-branches"
+	tagModeCurrentBranch = "current-branch"
+
+	notesFormatMarkdown = "markdown"
+	notesFormatJSON     = "json"
+
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// quietOutput silences info/warn logging while --output json is active, so
+// stdout carries nothing but the plan document.
+var quietOutput bool
+
 // 1. Determine the latest version (enum tags?)
 // 2. Fetch commits since latest tag
 // 3. Calculate version based on commits
@@ -48,10 +65,16 @@ func abort(f string, args ...interface{}) {
 }
 
 func info(f string, args ...interface{}) {
+	if quietOutput {
+		return
+	}
 	fmt.Printf("+ %s\n", fmt.Sprintf(f, args...))
 }
 
 func warn(f string, args ...interface{}) {
+	if quietOutput {
+		return
+	}
 	fmt.Printf("! %s\n", fmt.Sprintf(f, args...))
 }
 
@@ -61,13 +84,36 @@ type Context struct {
 	Rules      map[string]string
 	Categories map[string]string
 	Ignore     []string
+	PreRelease string
+	Build      string
+	TagMode    string
+
+	NotesTemplate string
+	NotesFormat   string
+	ChangelogFile string
+
+	Provider      string
+	Repository    string
+	GitLabBaseURL string
+	GiteaBaseURL  string
+
+	Components        []monorepo.Component
+	RequireScopeMatch bool
+
+	SigningKey           string
+	SigningKeyPassphrase string
+
+	Output string
+	DryRun bool
 }
 
 func run(c *Context) {
 	repoPath := os.Getenv("GITHUB_WORKSPACE")
-	repoFullName := os.Getenv("GITHUB_REPOSITORY")
-	repoComponents := strings.Split(repoFullName, "/")
-	repoOwner, repoName := repoComponents[0], repoComponents[1]
+
+	if c.DryRun {
+		c.Push = false
+	}
+	quietOutput = c.Output == outputJSON
 
 	for _, r := range c.Rules {
 		r = strings.ToLower(r)
@@ -77,6 +123,30 @@ func run(c *Context) {
 		}
 	}
 
+	if c.TagMode != tagModeAllBranches && c.TagMode != tagModeCurrentBranch {
+		abort("Invalid tag mode '%s'", c.TagMode)
+	}
+
+	if c.NotesFormat != notesFormatMarkdown && c.NotesFormat != notesFormatJSON {
+		abort("Invalid notes format '%s'", c.NotesFormat)
+	}
+
+	if c.Provider != "" && c.Provider != provider.GitHub && c.Provider != provider.GitLab && c.Provider != provider.Gitea {
+		abort("Invalid provider '%s'", c.Provider)
+	}
+
+	if c.Output != outputText && c.Output != outputJSON {
+		abort("Invalid output mode '%s'", c.Output)
+	}
+
+	if len(c.Components) > 0 {
+		if c.Output == outputJSON {
+			abort("--output json is not supported together with --component")
+		}
+		runMonorepo(c, repoPath)
+		return
+	}
+
 	runInfo := []string{
 		"semver-releaser v2",
 		"https://github.com/heyvito/semver-releaser",
@@ -115,14 +185,23 @@ func run(c *Context) {
 		}
 	}
 
-	fmt.Println(strings.Join(runInfo, "\n"))
-	fmt.Println()
+	if !quietOutput {
+		fmt.Println(strings.Join(runInfo, "\n"))
+		fmt.Println()
+	}
 
 	repo, err := git.PlainOpen(repoPath)
 	if err != nil {
 		abort("Could not open %s: %s", repoPath, err)
 	}
 
+	publisher := newPublisher(c, repo)
+
+	branchHead, err := resolveBranchHead(c, repo)
+	if err != nil {
+		abort("Error reading head: %s", err)
+	}
+
 	// Determine latest version
 	allTags, err := repo.Tags()
 	if err != nil {
@@ -131,9 +210,21 @@ func run(c *Context) {
 
 	var tags Versions
 	err = allTags.ForEach(func(ref *plumbing.Reference) error {
-		if semver.IsValid(ref.Name().Short()) {
-			tags = append(tags, ref.Name().Short())
+		if !semver.IsValid(ref.Name().Short()) {
+			return nil
 		}
+
+		if branchHead != nil {
+			commit, cerr := releaser.ResolveTagCommit(repo, ref)
+			if cerr != nil {
+				return nil
+			}
+			if ancestor, aerr := commit.IsAncestor(branchHead); aerr != nil || !ancestor {
+				return nil
+			}
+		}
+
+		tags = append(tags, ref.Name().Short())
 		return nil
 	})
 
@@ -155,65 +246,49 @@ func run(c *Context) {
 		if err != nil {
 			abort("Could not read tag %s: %s", latestVersion, err)
 		}
-		head = tag.Hash()
+		commit, err := releaser.ResolveTagCommit(repo, tag)
+		if err != nil {
+			abort("Could not resolve tag %s: %s", latestVersion, err)
+		}
+		head = commit.Hash
 	} else {
 		warn("No SemVer tag found. Assuming as first release...")
-		var lastCommit *object.Commit
-		log, err := repo.Log(&git.LogOptions{})
+		lastCommit, err := releaser.FirstCommitHash(repo)
 		if err != nil {
 			abort("Error enumerating commits: %s", err)
 		}
 
-		for {
-			c, err := log.Next()
-			if err == io.EOF {
-				break
-			}
-			lastCommit = c
-		}
-
-		if lastCommit == nil {
-			abort("Repository does not have a commit")
-			return
-		}
-
-		head = lastCommit.Hash
+		head = lastCommit
 		latestVersion = "v0.0.0"
 	}
 
 	info("Last release is at %s", head)
-	commits, err := repo.Log(&git.LogOptions{
-		All: true,
-	})
+	commits, err := releaser.CommitsSince(repo, head)
 	if err != nil {
 		abort("Error reading commits: %s", err)
 	}
 
 	excluded := 0
-	var conventionals Commits
-	for {
-		commit, err := commits.Next()
-		if err != nil {
-			abort("Error iterating commits: %s", err)
-		}
-		if commit.Hash == head {
-			break
-		}
-		if conv := ParseCommit(strings.TrimSpace(commit.Message)); conv != nil {
-			for _, v := range c.Ignore {
-				if strings.ToLower(v) == conv.Type {
-					excluded++
-					continue
-				}
+	var conventionals conventional.Commits
+	var excludedCommits conventional.Commits
+	for _, commit := range commits {
+		if conv := conventional.ParseCommit(strings.TrimSpace(commit.Message)); conv != nil {
+			if releaser.Ignored(conv.Type, c.Ignore) {
+				excluded++
+				excludedCommits = append(excludedCommits, conv)
+			} else {
+				conventionals = append(conventionals, conv)
 			}
-			conventionals = append(conventionals, conv)
 		} else {
 			warn("Ignoring non-standard commit: %s", strings.Split(commit.Message, "\n")[0])
 		}
 	}
-	commits.Close()
 
 	if len(conventionals) == 0 {
+		if c.Output == outputJSON {
+			printPlan(buildPlan(c, publisher, latestVersion, "", conventional.SemVerNone, conventionals, excludedCommits))
+			return
+		}
 		info("No new commits to release.")
 		return
 	}
@@ -223,24 +298,32 @@ func run(c *Context) {
 		info("%d commit(s) matched the 'ignore' flag and were excluded", excluded)
 	}
 
-	major, minor, patch := parseSemVer(latestVersion)
-	bumpKind := determineBump(c, conventionals)
-	switch bumpKind {
-	case SemVerPatch:
-		patch++
-	case SemVerMinor:
-		patch = 0
-		minor++
-	case SemVerMajor:
-		patch = 0
-		minor = 0
-		major++
-	case SemVerNone:
+	currentVersion, err := version.Parse(latestVersion)
+	if err != nil {
+		abort("Error parsing %s: %s", latestVersion, err)
+	}
+
+	bumpKind := conventional.DetermineBump(c.Rules, conventionals)
+	if bumpKind == conventional.SemVerNone && !(c.PreRelease != "" && currentVersion.Prerelease != "") {
+		if c.Output == outputJSON {
+			printPlan(buildPlan(c, publisher, latestVersion, "", bumpKind, conventionals, excludedCommits))
+			return
+		}
 		info("No need to bump version.")
 		return
 	}
 
-	nextVersion := fmt.Sprintf("v%d.%d.%d", major, minor, patch)
+	nextVersionValue := version.Bump(currentVersion, bumpKind, version.BumpOptions{
+		PreRelease: c.PreRelease,
+		Build:      c.Build,
+	})
+	nextVersion := nextVersionValue.String()
+
+	if c.Output == outputJSON {
+		printPlan(buildPlan(c, publisher, latestVersion, nextVersion, bumpKind, conventionals, excludedCommits))
+		return
+	}
+
 	info("Releasing %s", nextVersion)
 
 	fmt.Printf("::set-output name=version::%s\n", nextVersion)
@@ -254,52 +337,38 @@ func run(c *Context) {
 		abort("Error reading head: %s", err)
 	}
 
-	tag, err := repo.CreateTag(nextVersion, currentHead.Hash(), nil)
+	notes := buildReleaseNotes(c, publisher, conventionals, nextVersion, latestVersion)
+	releaseText, err := renderReleaseNotes(c, notes)
 	if err != nil {
-		abort("Error tagging %s: %s", nextVersion, err)
+		abort("Error rendering release notes: %s", err)
 	}
 
-	info("Created tag %s", tag.Hash())
-
-	remoteName := "__semver_releaser_http"
-	// Create a random remote and push to it
-	if _, err = repo.Remote(remoteName); err == git.ErrRemoteNotFound {
-		info("Created helper remote %s", remoteName)
-		_, err = repo.CreateRemote(&config.RemoteConfig{
-			Name: remoteName,
-			URLs: []string{"https://github.com/" + repoFullName + ".git"},
-		})
+	tag, err := createTag(c, repo, nextVersion, currentHead.Hash(), releaseText)
+	if err != nil {
+		abort("Error tagging %s: %s", nextVersion, err)
 	}
 
-	err = repo.Push(&git.PushOptions{
-		RemoteName: remoteName,
-		RefSpecs: []config.RefSpec{
-			config.RefSpec("+refs/tags/" + nextVersion + ":refs/tags/" + nextVersion),
-		},
-		Auth: &http.BasicAuth{
-			Username: "x-access-token",
-			Password: c.Token,
-		},
-	})
+	info("Created tag %s", tag.Hash())
 
-	if err != nil {
+	ctx := context.Background()
+	if err := publisher.PushTag(ctx, repo, nextVersion); err != nil {
 		abort("Error pushing: %s", err)
 	}
 
 	info("Pushed tag")
-	releaseText := makeReleaseText(c, conventionals)
 
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token})
-	tc := oauth2.NewClient(ctx, ts)
-
-	client := github.NewClient(tc)
-	_, _, err = client.Repositories.CreateRelease(ctx, repoOwner, repoName, &github.RepositoryRelease{
-		TagName:    github.String(nextVersion),
-		Name:       github.String(nextVersion),
-		Body:       github.String(releaseText),
-		Draft:      github.Bool(false),
-		Prerelease: github.Bool(false),
+	if c.ChangelogFile != "" {
+		if err := writeChangelog(c.ChangelogFile, releaseText); err != nil {
+			abort("Error writing %s: %s", c.ChangelogFile, err)
+		}
+		info("Updated %s", c.ChangelogFile)
+	}
+
+	err = publisher.CreateRelease(ctx, provider.ReleaseRequest{
+		Tag:        nextVersion,
+		Name:       nextVersion,
+		Body:       releaseText,
+		Prerelease: nextVersionValue.Prerelease != "",
 	})
 
 	if err != nil {
@@ -308,195 +377,507 @@ func run(c *Context) {
 
 }
 
-var semverString = map[string]SemVerComponent{
-	"patch": SemVerPatch,
-	"minor": SemVerMinor,
-	"major": SemVerMajor,
+// repositorySlug returns the "owner/repo" slug the tool should operate on,
+// preferring the explicit --repository flag over the legacy
+// GITHUB_REPOSITORY environment variable GitHub Actions provides.
+func repositorySlug(c *Context) string {
+	if c.Repository != "" {
+		return c.Repository
+	}
+	return os.Getenv("GITHUB_REPOSITORY")
+}
+
+func repositoryOwnerName(c *Context) (owner, name string) {
+	if parts := strings.SplitN(repositorySlug(c), "/", 2); len(parts) == 2 {
+		owner, name = parts[0], parts[1]
+	}
+	return
 }
 
-func semverFromString(n string) SemVerComponent {
-	n = strings.TrimSpace(strings.ToLower(n))
-	if v, ok := semverString[n]; ok {
-		return v
+// newPublisher selects a provider.ReleasePublisher based on --provider, or,
+// failing that, by sniffing the "origin" remote's URL.
+func newPublisher(c *Context, repo *git.Repository) provider.ReleasePublisher {
+	kind := c.Provider
+	if kind == "" {
+		kind = detectProviderKind(repo)
 	}
 
-	return SemVerNone
+	owner, name := repositoryOwnerName(c)
+
+	switch kind {
+	case provider.GitLab:
+		return provider.NewGitLab(c.GitLabBaseURL, repositorySlug(c), c.Token)
+	case provider.Gitea:
+		return provider.NewGitea(c.GiteaBaseURL, owner, name, c.Token)
+	default:
+		return provider.NewGitHub(owner, name, c.Token)
+	}
 }
 
-func determineBump(c *Context, commits Commits) SemVerComponent {
-	bang := SemVerNone
-	_, hasBang := c.Rules["bang"]
-	components := map[SemVerComponent][]string{}
-	toBump := SemVerNone
+func detectProviderKind(repo *git.Repository) string {
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return ""
+	}
+	cfg := remote.Config()
+	if len(cfg.URLs) == 0 {
+		return ""
+	}
+	return provider.DetectFromRemote(cfg.URLs[0])
+}
 
-	for ruleName, kind := range c.Rules {
-		if ruleName == "bang" {
-			bang = semverFromString(kind)
-			continue
+// resolveBranchHead returns the commit tags must be an ancestor of when
+// --tag-mode is current-branch, or nil when tags from any branch are
+// considered (the all-branches default).
+func resolveBranchHead(c *Context, repo *git.Repository) (*object.Commit, error) {
+	if c.TagMode != tagModeCurrentBranch {
+		return nil, nil
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	return repo.CommitObject(ref.Hash())
+}
+
+// filterByScope keeps only commits whose Conventional Commits scope matches
+// name, used to require a component's commits to be explicitly scoped to it
+// when --require-scope-match is set.
+func filterByScope(commits conventional.Commits, name string) conventional.Commits {
+	var out conventional.Commits
+	for _, commit := range commits {
+		if strings.EqualFold(commit.Scope, name) {
+			out = append(out, commit)
 		}
+	}
+	return out
+}
 
-		k := semverFromString(kind)
-		components[k] = append(components[k], ruleName)
+// runMonorepo releases each configured --component independently: every
+// component gets its own tag enumeration, commit walk, bump decision, tag,
+// and (optionally) release.
+func runMonorepo(c *Context, repoPath string) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		abort("Could not open %s: %s", repoPath, err)
 	}
 
-	comps := []SemVerComponent{SemVerMajor, SemVerMinor, SemVerPatch}
+	branchHead, err := resolveBranchHead(c, repo)
+	if err != nil {
+		abort("Error reading head: %s", err)
+	}
 
-	for _, r := range commits {
-		if toBump == SemVerMajor {
-			break
-		}
+	publisher := newPublisher(c, repo)
+	ctx := context.Background()
 
-		if r.Bang && hasBang {
-			if bang > toBump {
-				toBump = bang
-				continue
-			}
+	for _, comp := range c.Components {
+		runComponent(c, repo, comp, branchHead, publisher, ctx)
+	}
+}
+
+// runComponent releases a single monorepo component, mirroring run()'s
+// single-repository pipeline but scoped to comp.Directory and tagged with
+// comp.TagPrefix.
+func runComponent(c *Context, repo *git.Repository, comp monorepo.Component, branchHead *object.Commit, publisher provider.ReleasePublisher, ctx context.Context) {
+	info("Processing component '%s' (%s)", comp.Name, comp.Directory)
+
+	allTags, err := repo.Tags()
+	if err != nil {
+		abort("Could not enumerate tags for %s: %s", comp.Name, err)
+	}
+
+	var tags Versions
+	tagRefs := map[string]*plumbing.Reference{}
+	err = allTags.ForEach(func(ref *plumbing.Reference) error {
+		v, ok := comp.MatchTag(ref.Name().Short())
+		if !ok {
+			return nil
 		}
 
-		prefix := strings.ToLower(r.Type)
-	compLoop:
-		for _, v := range comps {
-			prefixes, ok := components[v]
-			if !ok {
-				continue
+		if branchHead != nil {
+			commit, cerr := releaser.ResolveTagCommit(repo, ref)
+			if cerr != nil {
+				return nil
 			}
-			if toBump > v {
-				continue
+			if ancestor, aerr := commit.IsAncestor(branchHead); aerr != nil || !ancestor {
+				return nil
 			}
+		}
 
-			for _, pr := range prefixes {
-				if strings.ToLower(pr) == prefix {
-					toBump = v
-					break compLoop
-				}
+		tags = append(tags, v)
+		tagRefs[v] = ref
+		return nil
+	})
+	if err != nil {
+		abort("Could not iterate tags for %s: %s", comp.Name, err)
+	}
+
+	sort.Sort(tags)
+
+	var latestVersion string
+	var head plumbing.Hash
+	if tags.Len() > 0 {
+		latestVersion = tags[0]
+		commit, err := releaser.ResolveTagCommit(repo, tagRefs[latestVersion])
+		if err != nil {
+			abort("Could not resolve tag %s: %s", comp.TagName(latestVersion), err)
+		}
+		head = commit.Hash
+		info("Latest version for %s is %s", comp.Name, comp.TagName(latestVersion))
+	} else {
+		warn("No SemVer tag found for %s. Assuming as first release...", comp.Name)
+		commit, err := releaser.FirstCommitHash(repo)
+		if err != nil {
+			abort("Error enumerating commits for %s: %s", comp.Name, err)
+		}
+		head = commit
+		latestVersion = "v0.0.0"
+	}
+
+	commits, err := releaser.CommitsSince(repo, head)
+	if err != nil {
+		abort("Error reading commits for %s: %s", comp.Name, err)
+	}
+
+	excluded := 0
+	var conventionals conventional.Commits
+	for _, commit := range commits {
+		if touches, terr := comp.Touches(commit); terr != nil || !touches {
+			continue
+		}
+
+		if conv := conventional.ParseCommit(strings.TrimSpace(commit.Message)); conv != nil {
+			if releaser.Ignored(conv.Type, c.Ignore) {
+				excluded++
+			} else {
+				conventionals = append(conventionals, conv)
 			}
 		}
 	}
 
-	return toBump
-}
+	if len(conventionals) == 0 {
+		info("No new commits affecting %s.", comp.Directory)
+		return
+	}
 
-func formatCommit(c *ConventionalCommit) string {
-	if c.Scope != "" {
-		return fmt.Sprintf("- **%s**: %s", c.Scope, c.Description)
-	} else {
-		return fmt.Sprintf("- %s", c.Description)
+	info("Processing %d commit(s) for %s since %s", len(conventionals), comp.Name, head)
+	if excluded > 0 {
+		info("%d commit(s) matched the 'ignore' flag and were excluded", excluded)
+	}
+
+	bumpCommits := conventionals
+	if c.RequireScopeMatch {
+		bumpCommits = filterByScope(conventionals, comp.Name)
+	}
+
+	currentVersion, err := version.Parse(latestVersion)
+	if err != nil {
+		abort("Error parsing %s for %s: %s", latestVersion, comp.Name, err)
+	}
+
+	bumpKind := conventional.DetermineBump(c.Rules, bumpCommits)
+	if bumpKind == conventional.SemVerNone && !(c.PreRelease != "" && currentVersion.Prerelease != "") {
+		info("No need to bump %s.", comp.Name)
+		return
+	}
+
+	nextVersionValue := version.Bump(currentVersion, bumpKind, version.BumpOptions{
+		PreRelease: c.PreRelease,
+		Build:      c.Build,
+	})
+	nextVersion := nextVersionValue.String()
+	tagName := comp.TagName(nextVersion)
+	info("Releasing %s", tagName)
+
+	fmt.Printf("::set-output name=%s::%s\n", comp.OutputKey(), nextVersion)
+
+	if !c.Push {
+		return
+	}
+
+	currentHead, err := repo.Head()
+	if err != nil {
+		abort("Error reading head: %s", err)
+	}
+
+	notes := buildReleaseNotes(c, publisher, conventionals, tagName, comp.TagName(latestVersion))
+	releaseText, err := renderReleaseNotes(c, notes)
+	if err != nil {
+		abort("Error rendering release notes for %s: %s", comp.Name, err)
+	}
+
+	tag, err := createTag(c, repo, tagName, currentHead.Hash(), releaseText)
+	if err != nil {
+		abort("Error tagging %s: %s", tagName, err)
+	}
+
+	info("Created tag %s", tag.Hash())
+
+	if err := publisher.PushTag(ctx, repo, tagName); err != nil {
+		abort("Error pushing %s: %s", tagName, err)
+	}
+
+	info("Pushed tag %s", tagName)
+
+	if c.ChangelogFile != "" {
+		if err := writeChangelog(c.ChangelogFile, releaseText); err != nil {
+			abort("Error writing %s: %s", c.ChangelogFile, err)
+		}
+		info("Updated %s", c.ChangelogFile)
+	}
+
+	err = publisher.CreateRelease(ctx, provider.ReleaseRequest{
+		Tag:        tagName,
+		Name:       tagName,
+		Body:       releaseText,
+		Prerelease: nextVersionValue.Prerelease != "",
+	})
+
+	if err != nil {
+		abort("Error creating release for %s: %s", comp.Name, err)
 	}
 }
 
-func makeReleaseText(c *Context, commits Commits) string {
-	categories := map[string][]string{}
-	usesOther := false
-	var others []string
-	for cat := range c.Categories {
-		if cat == "*" {
-			usesOther = true
-			break
+// signingKeyMaterial returns the configured signing key's raw contents,
+// preferring an explicit --signing-key path over the SIGNING_KEY
+// environment variable CI providers commonly inject as a secret. An empty
+// result means tags should be created unsigned.
+func signingKeyMaterial(c *Context) (string, error) {
+	if c.SigningKey != "" {
+		data, err := os.ReadFile(c.SigningKey)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", c.SigningKey, err)
 		}
+		return string(data), nil
 	}
+	return os.Getenv("SIGNING_KEY"), nil
+}
 
-	for _, r := range commits {
-		commitType := strings.ToLower(r.Type)
+// createTag creates name's tag at hash. When a signing key is configured,
+// the tag is annotated with releaseText as its message and signed; it is
+// verified immediately after creation, and left local and unpushed if that
+// verification fails.
+func createTag(c *Context, repo *git.Repository, name string, hash plumbing.Hash, releaseText string) (*plumbing.Reference, error) {
+	material, err := signingKeyMaterial(c)
+	if err != nil {
+		return nil, err
+	}
+	if material == "" {
+		return repo.CreateTag(name, hash, nil)
+	}
+
+	key, err := signing.Load(material, c.SigningKeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("loading signing key: %w", err)
+	}
+
+	tagger := object.Signature{Name: "semver-releaser", Email: "semver-releaser@users.noreply.github.com", When: time.Now()}
+	ref, err := signing.CreateTag(repo, name, hash, tagger, releaseText, key)
+	if err != nil {
+		return nil, fmt.Errorf("creating signed tag: %w", err)
+	}
+
+	if err := signing.Verify(repo, name, key); err != nil {
+		return nil, fmt.Errorf("refusing to push unverifiable tag: %w", err)
+	}
+
+	return ref, nil
+}
+
+// buildReleaseNotes groups commits into the categories configured via
+// --categories, plus a dedicated section for breaking changes, and packages
+// them into the structured value fed to the release-notes template.
+func buildReleaseNotes(c *Context, publisher provider.ReleasePublisher, commits conventional.Commits, nextVersion, previousVersion string) releasenotes.ReleaseNotes {
+	_, usesOther := c.Categories["*"]
+
+	grouped := map[string]conventional.Commits{}
+	var others conventional.Commits
+	var breaking conventional.Commits
+
+	for _, commit := range commits {
+		if commit.Bang {
+			breaking = append(breaking, commit)
+		}
+
+		commitType := strings.ToLower(commit.Type)
 		match := false
 		for cat := range c.Categories {
 			if cat == "*" {
 				continue
 			}
-			if commitType == strings.ToLower(cat) {
-				var arr []string
-				if v, ok := categories[cat]; ok {
-					arr = v
-				}
-				categories[cat] = append(arr, formatCommit(r))
+			if strings.ToLower(cat) == commitType {
+				grouped[cat] = append(grouped[cat], commit)
 				match = true
 				break
 			}
 		}
 
 		if !match && usesOther {
-			if r.Scope != "" {
-				others = append(others, fmt.Sprintf("- %s(%s): %s", r.Type, r.Scope, r.Description))
-			} else {
-				others = append(others, fmt.Sprintf("- %s: %s", r.Type, r.Description))
-			}
+			others = append(others, commit)
 		}
 	}
 
-	var output []string
-
+	var sections []releasenotes.Section
 	for id, title := range c.Categories {
-		if items, ok := categories[strings.ToLower(id)]; ok {
-			output = append(output, fmt.Sprintf("# %s", title))
-			output = append(output, items...)
+		if id == "*" {
+			continue
+		}
+		if items, ok := grouped[id]; ok {
+			sections = append(sections, releasenotes.Section{Name: title, Commits: items})
 		}
 	}
+	if usesOther && len(others) > 0 {
+		sections = append(sections, releasenotes.Section{Name: c.Categories["*"], Commits: others})
+	}
+
+	owner, name := repositoryOwnerName(c)
 
-	return strings.Join(output, "\n")
+	return releasenotes.ReleaseNotes{
+		Version:         nextVersion,
+		PreviousVersion: previousVersion,
+		Date:            time.Now(),
+		Sections:        sections,
+		BreakingChanges: breaking,
+		RepoOwner:       owner,
+		RepoName:        name,
+		CommitURLFunc:   publisher.CommitURL,
+		CompareURLFunc:  publisher.CompareURL,
+		AuthorURLFunc:   publisher.ProfileURL,
+	}
 }
 
-func parseSemVer(v string) (major, minor, patch int) {
-	rawComponents := strings.Split(strings.TrimPrefix(v, "v"), ".")
-	major, _ = strconv.Atoi(rawComponents[0])
-	minor, _ = strconv.Atoi(rawComponents[1])
-	patch, _ = strconv.Atoi(rawComponents[2])
-	return
+// renderReleaseNotes renders notes using --notes-template (or the bundled
+// default) in markdown mode, or marshals notes as-is in json mode.
+func renderReleaseNotes(c *Context, notes releasenotes.ReleaseNotes) (string, error) {
+	if c.NotesFormat == notesFormatJSON {
+		return releasenotes.RenderJSON(notes)
+	}
+
+	return renderReleaseNotesMarkdown(c, notes)
 }
 
-type SemVerComponent int
+// renderReleaseNotesMarkdown renders notes as markdown, ignoring
+// --notes-format - used for the release_notes_markdown field of an
+// --output json plan, which is always markdown regardless of how the
+// actual release body would be formatted.
+func renderReleaseNotesMarkdown(c *Context, notes releasenotes.ReleaseNotes) (string, error) {
+	tmplSource := ""
+	if c.NotesTemplate != "" {
+		data, err := os.ReadFile(c.NotesTemplate)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", c.NotesTemplate, err)
+		}
+		tmplSource = string(data)
+	}
 
-const (
-	SemVerNone SemVerComponent = iota
-	SemVerPatch
-	SemVerMinor
-	SemVerMajor
-)
+	return releasenotes.Render(notes, tmplSource)
+}
 
-type ConventionalCommit struct {
-	Type         string
-	SemVerChange SemVerComponent
-	Scope        string
-	Description  string
-	Body         string
-	Bang         bool
+// planCommit is the machine-readable shape of a single commit in an
+// --output json plan.
+type planCommit struct {
+	Type        string   `json:"type"`
+	Scope       string   `json:"scope,omitempty"`
+	Description string   `json:"description"`
+	Breaking    bool     `json:"breaking"`
+	Refs        []string `json:"refs,omitempty"`
+	PullRequest *int     `json:"pull_request,omitempty"`
 }
 
-type Commits []*ConventionalCommit
+func toPlanCommits(commits conventional.Commits) []planCommit {
+	out := make([]planCommit, 0, len(commits))
+	for _, commit := range commits {
+		out = append(out, planCommit{
+			Type:        commit.Type,
+			Scope:       commit.Scope,
+			Description: commit.Description,
+			Breaking:    commit.Bang,
+			Refs:        commit.Refs,
+			PullRequest: commit.PullRequest,
+		})
+	}
+	return out
+}
 
-var conventionalRegexp = regexp.MustCompile(`^([^(:!]+)(?:\(([^)]+)\))?(!)?: ([^\n]+)$`)
-var multiLineCommit = regexp.MustCompile(`(.+)\n{2,}(.+\n*)+`)
+// releasePlan is the document --output json writes to stdout: a full
+// description of the release a real run would make, without making it.
+type releasePlan struct {
+	PreviousVersion      string              `json:"previous_version"`
+	NextVersion          string              `json:"next_version,omitempty"`
+	Bump                 string              `json:"bump"`
+	Commits              []planCommit        `json:"commits"`
+	Excluded             []planCommit        `json:"excluded"`
+	Categories           map[string][]string `json:"categories,omitempty"`
+	ReleaseNotesMarkdown string              `json:"release_notes_markdown,omitempty"`
+}
 
-func ParseCommit(msg string) *ConventionalCommit {
-	if multiLineCommit.MatchString(msg) {
-		lines := strings.Split(msg, "\n")
-		res := ParseCommit(lines[0])
-		if res == nil {
-			return nil
+// buildPlan assembles a releasePlan from the same values run() would use to
+// actually cut a release. nextVersion is blank when there's nothing to
+// release, in which case no release notes are rendered.
+func buildPlan(c *Context, publisher provider.ReleasePublisher, previousVersion, nextVersion string, bump conventional.SemVerComponent, commits, excludedCommits conventional.Commits) releasePlan {
+	plan := releasePlan{
+		PreviousVersion: previousVersion,
+		NextVersion:     nextVersion,
+		Bump:            bump.String(),
+		Commits:         toPlanCommits(commits),
+		Excluded:        toPlanCommits(excludedCommits),
+	}
+
+	if nextVersion != "" {
+		notes := buildReleaseNotes(c, publisher, commits, nextVersion, previousVersion)
+		plan.Categories = categoriesFromSections(notes.Sections)
+
+		releaseText, err := renderReleaseNotesMarkdown(c, notes)
+		if err != nil {
+			abort("Error rendering release notes: %s", err)
 		}
-		for _, l := range lines[1:] {
-			if strings.HasPrefix(strings.ToLower(l), "breaking change:") {
-				res.Bang = true
-			}
+		plan.ReleaseNotesMarkdown = releaseText
+	}
+
+	return plan
+}
+
+// categoriesFromSections reduces release-notes sections down to their
+// commit descriptions, the shape exposed under a plan's "categories" key.
+func categoriesFromSections(sections []releasenotes.Section) map[string][]string {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	out := make(map[string][]string, len(sections))
+	for _, s := range sections {
+		descriptions := make([]string, 0, len(s.Commits))
+		for _, commit := range s.Commits {
+			descriptions = append(descriptions, commit.Description)
 		}
-		res.Body = strings.Join(lines[1:], "\n")
+		out[s.Name] = descriptions
+	}
+	return out
+}
 
-		return res
+// printPlan marshals plan as indented JSON and writes it to stdout.
+func printPlan(plan interface{}) {
+	out, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		abort("Error encoding plan: %s", err)
 	}
+	fmt.Println(string(out))
+}
 
-	if !conventionalRegexp.MatchString(msg) {
-		return nil
+// writeChangelog prepends the rendered release notes to path, creating it
+// if it doesn't yet exist, so CHANGELOG.md stays ordered newest-first.
+func writeChangelog(path, entry string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
 	}
 
-	opts := conventionalRegexp.FindStringSubmatch(msg)
-	var kind, scope, bang, change = opts[1], opts[2], opts[3], opts[4]
-	res := &ConventionalCommit{
-		Type:        kind,
-		Scope:       scope,
-		Description: change,
-		Bang:        bang == "!",
-		Body:        "",
+	content := entry + "\n"
+	if len(existing) > 0 {
+		content += "\n" + string(existing)
 	}
 
-	return res
+	return os.WriteFile(path, []byte(content), 0644)
 }
 
 func main() {
@@ -508,6 +889,22 @@ func main() {
 			&cli.StringFlag{Name: "rules", Required: true},
 			&cli.StringFlag{Name: "categories", Required: true},
 			&cli.StringFlag{Name: "ignore", Required: false},
+			&cli.StringFlag{Name: "pre-release", Required: false},
+			&cli.StringFlag{Name: "build", Required: false},
+			&cli.StringFlag{Name: "tag-mode", Required: false, Value: tagModeAllBranches},
+			&cli.StringFlag{Name: "notes-template", Required: false},
+			&cli.StringFlag{Name: "notes-format", Required: false, Value: notesFormatMarkdown},
+			&cli.StringFlag{Name: "changelog-file", Required: false},
+			&cli.StringFlag{Name: "provider", Required: false},
+			&cli.StringFlag{Name: "repository", Required: false},
+			&cli.StringFlag{Name: "gitlab-url", Required: false},
+			&cli.StringFlag{Name: "gitea-url", Required: false},
+			&cli.StringSliceFlag{Name: "component", Required: false, Usage: "monorepo component as \"name:directory:tag-prefix\", repeatable"},
+			&cli.BoolFlag{Name: "require-scope-match", Required: false, Usage: "only bump a component from commits whose scope matches its name"},
+			&cli.StringFlag{Name: "signing-key", Required: false, Usage: "path to an armored PGP or SSH private key used to sign release tags"},
+			&cli.StringFlag{Name: "signing-key-passphrase", Required: false},
+			&cli.StringFlag{Name: "output", Required: false, Value: outputText, Usage: "text, or json to write a single machine-readable release plan to stdout"},
+			&cli.BoolFlag{Name: "dry-run", Required: false, Usage: "compute the release but never push a tag or create a release"},
 		},
 		Action: func(c *cli.Context) error {
 			rules, err := eql.Parse(c.String("rules"))
@@ -527,12 +924,39 @@ func main() {
 				ignore = strings.Split(rawIgnore, " ")
 			}
 
+			components, err := monorepo.ParseComponents(c.StringSlice("component"))
+			if err != nil {
+				fmt.Printf("Error parsing components: %s\n", err)
+				os.Exit(1)
+			}
+
 			ctx := Context{
 				Token:      c.String("token"),
 				Push:       c.String("push") == "true",
 				Rules:      rules,
 				Categories: cats,
 				Ignore:     ignore,
+				PreRelease: c.String("pre-release"),
+				Build:      c.String("build"),
+				TagMode:    c.String("tag-mode"),
+
+				NotesTemplate: c.String("notes-template"),
+				NotesFormat:   c.String("notes-format"),
+				ChangelogFile: c.String("changelog-file"),
+
+				Provider:      c.String("provider"),
+				Repository:    c.String("repository"),
+				GitLabBaseURL: c.String("gitlab-url"),
+				GiteaBaseURL:  c.String("gitea-url"),
+
+				Components:        components,
+				RequireScopeMatch: c.Bool("require-scope-match"),
+
+				SigningKey:           c.String("signing-key"),
+				SigningKeyPassphrase: c.String("signing-key-passphrase"),
+
+				Output: c.String("output"),
+				DryRun: c.Bool("dry-run"),
 			}
 
 			run(&ctx)
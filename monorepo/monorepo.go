@@ -0,0 +1,88 @@
+// Package monorepo implements per-directory component scoping, so a single
+// repository can host several independently-versioned packages, each tagged
+// and released on its own.
+package monorepo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/mod/semver"
+)
+
+// Component describes one independently-versioned package living under its
+// own directory, tagged with its own prefix (e.g. "api/" producing tags like
+// "api/v1.2.0").
+type Component struct {
+	Name      string
+	Directory string
+	TagPrefix string
+}
+
+// ParseComponents parses repeated --component flags in "name:directory:tag-
+// prefix" form into a list of Components.
+func ParseComponents(raw []string) ([]Component, error) {
+	components := make([]Component, 0, len(raw))
+	for _, r := range raw {
+		parts := strings.SplitN(r, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid --component %q, expected \"name:directory:tag-prefix\"", r)
+		}
+		components = append(components, Component{Name: parts[0], Directory: parts[1], TagPrefix: parts[2]})
+	}
+	return components, nil
+}
+
+// MatchTag strips the component's tag prefix from tagName and reports
+// whether what remains is a valid SemVer version.
+func (c Component) MatchTag(tagName string) (version string, ok bool) {
+	if !strings.HasPrefix(tagName, c.TagPrefix) {
+		return "", false
+	}
+	version = strings.TrimPrefix(tagName, c.TagPrefix)
+	if !semver.IsValid(version) {
+		return "", false
+	}
+	return version, true
+}
+
+// TagName renders the full tag name for one of the component's versions,
+// e.g. "api/v1.2.0".
+func (c Component) TagName(version string) string {
+	return c.TagPrefix + version
+}
+
+// Touches reports whether commit changed any file under the component's
+// directory.
+func (c Component) Touches(commit *object.Commit) (bool, error) {
+	stats, err := commit.Stats()
+	if err != nil {
+		return false, fmt.Errorf("computing stats for %s: %w", commit.Hash, err)
+	}
+
+	dir := strings.TrimSuffix(c.Directory, "/")
+	prefix := dir + "/"
+	for _, stat := range stats {
+		if stat.Name == dir || strings.HasPrefix(stat.Name, prefix) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// OutputKey returns the ::set-output variable name for this component's
+// version, e.g. "version_api".
+func (c Component) OutputKey() string {
+	var b strings.Builder
+	b.WriteString("version_")
+	for _, r := range c.Name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,292 @@
+// Package releaser exposes semver-releaser's version-planning pipeline as a
+// library, so the same commit-analysis and bump rules used by the CLI can be
+// embedded into other Go programs instead of only being invoked as a
+// standalone tool.
+package releaser
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/heyvito/semver-releaser/conventional"
+	"github.com/heyvito/semver-releaser/version"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// TagModeAllBranches considers every SemVer tag in the repository when
+	// computing the latest version. This is the default.
+	TagModeAllBranches = "all-branches"
+	// TagModeCurrentBranch only considers tags reachable from HEAD.
+	TagModeCurrentBranch = "current-branch"
+)
+
+type options struct {
+	repoPath   string
+	rules      map[string]string
+	ignore     []string
+	preRelease string
+	build      string
+	tagMode    string
+}
+
+// Option configures a call to Next or Current.
+type Option func(*options)
+
+// WithRepoPath sets the path to the git repository to operate on. Defaults
+// to the current directory.
+func WithRepoPath(path string) Option {
+	return func(o *options) { o.repoPath = path }
+}
+
+// WithRules sets the commit-type -> SemVer component rules, in the same
+// shape produced by eql.Parse for the CLI's --rules flag.
+func WithRules(rules map[string]string) Option {
+	return func(o *options) { o.rules = rules }
+}
+
+// WithIgnore sets commit type prefixes that should be excluded from the
+// bump calculation.
+func WithIgnore(ignore []string) Option {
+	return func(o *options) { o.ignore = ignore }
+}
+
+// WithPreRelease marks the next version as a prerelease using the given
+// identifier (e.g. "rc", "beta").
+func WithPreRelease(id string) Option {
+	return func(o *options) { o.preRelease = id }
+}
+
+// WithBuild attaches build metadata to the next version.
+func WithBuild(build string) Option {
+	return func(o *options) { o.build = build }
+}
+
+// WithTagMode selects which tags are considered when computing the latest
+// version: TagModeAllBranches (default) or TagModeCurrentBranch.
+func WithTagMode(mode string) Option {
+	return func(o *options) { o.tagMode = mode }
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{repoPath: ".", tagMode: TagModeAllBranches}
+	for _, apply := range opts {
+		apply(o)
+	}
+	return o
+}
+
+// Current returns the latest SemVer tag reachable under the given options,
+// or "v0.0.0" if the repository has none.
+func Current(opts ...Option) (string, error) {
+	o := newOptions(opts)
+	repo, err := git.PlainOpen(o.repoPath)
+	if err != nil {
+		return "", fmt.Errorf("opening repository: %w", err)
+	}
+
+	latest, _, err := latestTag(repo, o)
+	if err != nil {
+		return "", err
+	}
+	if latest == "" {
+		return "v0.0.0", nil
+	}
+	return latest, nil
+}
+
+// Next computes the next version that would be released given the
+// repository's commits since the latest version, applying rules, ignore
+// prefixes and the prerelease/build options configured above. It performs
+// no git writes, and returns the current version unchanged if no commit
+// warrants a bump.
+func Next(opts ...Option) (string, error) {
+	o := newOptions(opts)
+	repo, err := git.PlainOpen(o.repoPath)
+	if err != nil {
+		return "", fmt.Errorf("opening repository: %w", err)
+	}
+
+	latest, boundary, err := latestTag(repo, o)
+	if err != nil {
+		return "", err
+	}
+	if latest == "" {
+		latest = "v0.0.0"
+	}
+
+	commits, err := CommitsSince(repo, boundary)
+	if err != nil {
+		return "", err
+	}
+
+	var conventionals conventional.Commits
+	for _, c := range commits {
+		conv := conventional.ParseCommit(strings.TrimSpace(c.Message))
+		if conv == nil || Ignored(conv.Type, o.ignore) {
+			continue
+		}
+		conventionals = append(conventionals, conv)
+	}
+
+	cur, err := version.Parse(latest)
+	if err != nil {
+		return "", fmt.Errorf("parsing latest version %q: %w", latest, err)
+	}
+
+	bump := conventional.DetermineBump(o.rules, conventionals)
+	if bump == conventional.SemVerNone && !(o.preRelease != "" && cur.Prerelease != "") {
+		return cur.String(), nil
+	}
+
+	next := version.Bump(cur, bump, version.BumpOptions{PreRelease: o.preRelease, Build: o.build})
+	return next.String(), nil
+}
+
+// Ignored reports whether commitType matches one of the --ignore prefixes,
+// case-insensitively. Exported so callers that walk commits themselves (such
+// as the CLI's monorepo mode) apply the exact same rule Next does.
+func Ignored(commitType string, ignore []string) bool {
+	for _, v := range ignore {
+		if strings.EqualFold(v, commitType) {
+			return true
+		}
+	}
+	return false
+}
+
+// latestTag returns the latest matching tag name and the commit hash it
+// points to, or the repository's very first commit hash if no tag exists.
+func latestTag(repo *git.Repository, o *options) (string, plumbing.Hash, error) {
+	var headCommit *object.Commit
+	if o.tagMode == TagModeCurrentBranch {
+		head, err := repo.Head()
+		if err != nil {
+			return "", plumbing.ZeroHash, fmt.Errorf("reading HEAD: %w", err)
+		}
+		headCommit, err = repo.CommitObject(head.Hash())
+		if err != nil {
+			return "", plumbing.ZeroHash, fmt.Errorf("reading HEAD commit: %w", err)
+		}
+	}
+
+	allTags, err := repo.Tags()
+	if err != nil {
+		return "", plumbing.ZeroHash, fmt.Errorf("enumerating tags: %w", err)
+	}
+
+	var tagNames []string
+	tagHashes := map[string]plumbing.Hash{}
+	err = allTags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if !semver.IsValid(name) {
+			return nil
+		}
+
+		commit, cerr := ResolveTagCommit(repo, ref)
+		if cerr != nil {
+			return nil
+		}
+
+		if headCommit != nil {
+			anc, aerr := commit.IsAncestor(headCommit)
+			if aerr != nil || !anc {
+				return nil
+			}
+		}
+
+		tagNames = append(tagNames, name)
+		tagHashes[name] = commit.Hash
+		return nil
+	})
+	if err != nil {
+		return "", plumbing.ZeroHash, fmt.Errorf("iterating tags: %w", err)
+	}
+
+	sort.Slice(tagNames, func(i, j int) bool { return semver.Compare(tagNames[i], tagNames[j]) > 0 })
+
+	if len(tagNames) == 0 {
+		head, err := FirstCommitHash(repo)
+		if err != nil {
+			return "", plumbing.ZeroHash, err
+		}
+		return "", head, nil
+	}
+
+	latest := tagNames[0]
+	return latest, tagHashes[latest], nil
+}
+
+// ResolveTagCommit resolves the commit a tag reference points to, handling
+// both lightweight tags (whose hash already is the commit) and annotated
+// tags. Exported so callers enumerating tags themselves (such as the CLI's
+// monorepo mode, which matches tags against a component prefix before this
+// package ever sees them) share this resolution logic instead of
+// reimplementing it.
+func ResolveTagCommit(repo *git.Repository, ref *plumbing.Reference) (*object.Commit, error) {
+	if c, err := repo.CommitObject(ref.Hash()); err == nil {
+		return c, nil
+	}
+	tagObj, err := repo.TagObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return tagObj.Commit()
+}
+
+// FirstCommitHash walks the entire history and returns the hash of its very
+// first commit, used as the release boundary when nothing has been tagged
+// yet. Exported for the same reason as ResolveTagCommit.
+func FirstCommitHash(repo *git.Repository) (plumbing.Hash, error) {
+	log, err := repo.Log(&git.LogOptions{})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("enumerating commits: %w", err)
+	}
+
+	var last *object.Commit
+	for {
+		c, err := log.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("iterating commits: %w", err)
+		}
+		last = c
+	}
+	if last == nil {
+		return plumbing.ZeroHash, fmt.Errorf("repository has no commits")
+	}
+	return last.Hash, nil
+}
+
+// CommitsSince returns every commit reachable from any ref, in newest-first
+// order, down to (but excluding) boundary. Exported so callers that need to
+// apply extra filtering per commit (such as the CLI's monorepo mode, which
+// additionally checks whether a commit touches a component's directory) can
+// still share this walk instead of reimplementing it.
+func CommitsSince(repo *git.Repository, boundary plumbing.Hash) ([]*object.Commit, error) {
+	iter, err := repo.Log(&git.LogOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("reading commits: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []*object.Commit
+	for {
+		c, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("iterating commits: %w", err)
+		}
+		if c.Hash == boundary {
+			break
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// GiteaPublisher publishes releases via the Gitea Releases API. BaseURL has
+// no default, since Gitea is always self-hosted.
+type GiteaPublisher struct {
+	BaseURL string
+	Owner   string
+	Repo    string
+	Token   string
+}
+
+func NewGitea(baseURL, owner, repo, token string) *GiteaPublisher {
+	return &GiteaPublisher{BaseURL: strings.TrimRight(baseURL, "/"), Owner: owner, Repo: repo, Token: token}
+}
+
+func (g *GiteaPublisher) PushTag(_ context.Context, repo *git.Repository, tag string) error {
+	return pushTag(repo, tag, hostAuth{
+		remoteURL: fmt.Sprintf("%s/%s/%s.git", g.BaseURL, g.Owner, g.Repo),
+		username:  "token",
+		password:  g.Token,
+	})
+}
+
+func (g *GiteaPublisher) CreateRelease(ctx context.Context, req ReleaseRequest) error {
+	payload, err := json.Marshal(map[string]string{
+		"tag_name": req.Tag,
+		"name":     req.Name,
+		"body":     req.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding release payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v1/repos/%s/%s/releases", g.BaseURL, g.Owner, g.Repo)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "token "+g.Token)
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("calling Gitea API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Gitea API returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (g *GiteaPublisher) CompareURL(from, to string) string {
+	return fmt.Sprintf("%s/%s/%s/compare/%s...%s", g.BaseURL, g.Owner, g.Repo, from, to)
+}
+
+func (g *GiteaPublisher) CommitURL(hash string) string {
+	return fmt.Sprintf("%s/%s/%s/commit/%s", g.BaseURL, g.Owner, g.Repo, hash)
+}
+
+func (g *GiteaPublisher) ProfileURL(name string) string {
+	return fmt.Sprintf("%s/%s", g.BaseURL, name)
+}
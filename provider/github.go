@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	gogithub "github.com/google/go-github/v39/github"
+	"golang.org/x/oauth2"
+)
+
+// GitHubPublisher publishes releases to github.com using a repository-scoped
+// access token.
+type GitHubPublisher struct {
+	Owner string
+	Repo  string
+	Token string
+}
+
+func NewGitHub(owner, repo, token string) *GitHubPublisher {
+	return &GitHubPublisher{Owner: owner, Repo: repo, Token: token}
+}
+
+func (g *GitHubPublisher) PushTag(_ context.Context, repo *git.Repository, tag string) error {
+	return pushTag(repo, tag, hostAuth{
+		remoteURL: fmt.Sprintf("https://github.com/%s/%s.git", g.Owner, g.Repo),
+		username:  "x-access-token",
+		password:  g.Token,
+	})
+}
+
+func (g *GitHubPublisher) CreateRelease(ctx context.Context, req ReleaseRequest) error {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: g.Token})
+	client := gogithub.NewClient(oauth2.NewClient(ctx, ts))
+
+	_, _, err := client.Repositories.CreateRelease(ctx, g.Owner, g.Repo, &gogithub.RepositoryRelease{
+		TagName:    gogithub.String(req.Tag),
+		Name:       gogithub.String(req.Name),
+		Body:       gogithub.String(req.Body),
+		Draft:      gogithub.Bool(false),
+		Prerelease: gogithub.Bool(req.Prerelease),
+	})
+	return err
+}
+
+func (g *GitHubPublisher) CompareURL(from, to string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/compare/%s...%s", g.Owner, g.Repo, from, to)
+}
+
+func (g *GitHubPublisher) CommitURL(hash string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/commit/%s", g.Owner, g.Repo, hash)
+}
+
+func (g *GitHubPublisher) ProfileURL(name string) string {
+	return fmt.Sprintf("https://github.com/%s", name)
+}
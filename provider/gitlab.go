@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// GitLabPublisher publishes releases via the GitLab Releases API. BaseURL
+// defaults to https://gitlab.com, so self-hosted instances just need to
+// override it.
+type GitLabPublisher struct {
+	BaseURL string
+	Project string // "group/project", possibly with nested subgroups
+	Token   string
+}
+
+func NewGitLab(baseURL, project, token string) *GitLabPublisher {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com"
+	}
+	return &GitLabPublisher{BaseURL: strings.TrimRight(baseURL, "/"), Project: project, Token: token}
+}
+
+func (g *GitLabPublisher) PushTag(_ context.Context, repo *git.Repository, tag string) error {
+	return pushTag(repo, tag, hostAuth{
+		remoteURL: fmt.Sprintf("%s/%s.git", g.BaseURL, g.Project),
+		username:  "oauth2",
+		password:  g.Token,
+	})
+}
+
+func (g *GitLabPublisher) CreateRelease(ctx context.Context, req ReleaseRequest) error {
+	payload, err := json.Marshal(map[string]string{
+		"tag_name":    req.Tag,
+		"name":        req.Name,
+		"description": req.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding release payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/releases", g.BaseURL, url.PathEscape(g.Project))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("PRIVATE-TOKEN", g.Token)
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("calling GitLab API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("GitLab API returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (g *GitLabPublisher) CompareURL(from, to string) string {
+	return fmt.Sprintf("%s/%s/-/compare/%s...%s", g.BaseURL, g.Project, from, to)
+}
+
+func (g *GitLabPublisher) CommitURL(hash string) string {
+	return fmt.Sprintf("%s/%s/-/commit/%s", g.BaseURL, g.Project, hash)
+}
+
+func (g *GitLabPublisher) ProfileURL(name string) string {
+	return fmt.Sprintf("%s/%s", g.BaseURL, name)
+}
@@ -0,0 +1,95 @@
+// Package provider abstracts the git-host-specific parts of the release
+// pipeline - pushing the version tag and creating a release - behind a
+// common interface, so semver-releaser can run against GitHub, GitLab or
+// Gitea over HTTPS with a token.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+const (
+	GitHub = "github"
+	GitLab = "gitlab"
+	Gitea  = "gitea"
+)
+
+// ReleaseRequest describes a release to publish on the remote host.
+type ReleaseRequest struct {
+	Tag        string
+	Name       string
+	Body       string
+	Prerelease bool
+}
+
+// ReleasePublisher abstracts the git-host-specific parts of the release
+// pipeline.
+type ReleasePublisher interface {
+	// PushTag pushes an existing local tag to the host.
+	PushTag(ctx context.Context, repo *git.Repository, tag string) error
+	// CreateRelease publishes a release on the host from an already-pushed tag.
+	CreateRelease(ctx context.Context, req ReleaseRequest) error
+	// CompareURL returns a link to the host's diff between two revisions.
+	CompareURL(from, to string) string
+	// CommitURL returns a link to a single commit on the host.
+	CommitURL(hash string) string
+	// ProfileURL returns a link to a user's profile on the host.
+	ProfileURL(name string) string
+}
+
+// DetectFromRemote guesses a provider name ([GitHub], [GitLab] or [Gitea])
+// from a remote URL, for auto-selecting a provider when --provider is left
+// unset. It looks for "gitlab", "gitea" and then "github" anywhere in the
+// lower-cased URL, not only in its host, and returns "" if none appears.
+func DetectFromRemote(remoteURL string) string {
+	u := strings.ToLower(remoteURL)
+	switch {
+	case strings.Contains(u, "gitlab"):
+		return GitLab
+	case strings.Contains(u, "gitea"):
+		return Gitea
+	case strings.Contains(u, "github"):
+		return GitHub
+	default:
+		return ""
+	}
+}
+
+// hostAuth carries the push target and credentials shared by the HTTPS-based
+// publishers below.
+type hostAuth struct {
+	remoteURL string
+	username  string
+	password  string
+}
+
+// pushTag creates (or reuses) a helper remote pointing at the given host and
+// pushes tag to it, mirroring the approach the GitHub integration has always
+// used.
+func pushTag(repo *git.Repository, tag string, auth hostAuth) error {
+	const remoteName = "__semver_releaser_http"
+
+	if _, err := repo.Remote(remoteName); err == git.ErrRemoteNotFound {
+		if _, err := repo.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{auth.remoteURL},
+		}); err != nil {
+			return fmt.Errorf("creating remote: %w", err)
+		}
+	}
+
+	return repo.Push(&git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec("+refs/tags/" + tag + ":refs/tags/" + tag)},
+		Auth: &githttp.BasicAuth{
+			Username: auth.username,
+			Password: auth.password,
+		},
+	})
+}
@@ -0,0 +1,116 @@
+// Package releasenotes renders release notes from a structured ReleaseNotes
+// value using Go's text/template, so projects can fully customize what gets
+// posted as a GitHub release body or written to CHANGELOG.md without
+// touching the tool's source.
+package releasenotes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/heyvito/semver-releaser/conventional"
+)
+
+// Section groups commits under a named category, e.g. "Features".
+type Section struct {
+	Name    string
+	Commits conventional.Commits
+}
+
+// ReleaseNotes is the structured value fed into a release-notes template.
+type ReleaseNotes struct {
+	Version         string
+	PreviousVersion string
+	Date            time.Time
+	Sections        []Section
+	BreakingChanges conventional.Commits
+	RepoOwner       string
+	RepoName        string
+
+	// CommitURLFunc, CompareURLFunc and AuthorURLFunc build links against
+	// the configured provider's host, so templates stay correct for
+	// GitHub, GitLab and Gitea alike. When one is nil, its template helper
+	// degrades: commitURL to the bare hash, authorLink to the bare name,
+	// and compareURL to an empty string. Excluded from JSON output, since
+	// funcs aren't marshalable.
+	CommitURLFunc  func(hash string) string     `json:"-"`
+	CompareURLFunc func(from, to string) string `json:"-"`
+	AuthorURLFunc  func(name string) string     `json:"-"`
+}
+
+// DefaultTemplate is used when no --notes-template is supplied.
+const DefaultTemplate = `# {{.Version}} ({{timefmt .Date "2006-01-02"}})
+{{range .Sections}}
+## {{.Name}}
+{{range .Commits}}{{if .Scope}}- **{{.Scope}}**: {{.Description}}{{else}}- {{.Description}}{{end}}{{if .PullRequest}} (#{{.PullRequest}}){{end}}{{range .CoAuthors}} (with {{authorLink .Name}}){{end}}
+{{end}}{{end}}{{if .BreakingChanges}}
+## BREAKING CHANGES
+{{range .BreakingChanges}}- {{.Description}}
+{{end}}{{end}}`
+
+// funcMap builds the template.FuncMap available to release-notes templates.
+func funcMap(notes ReleaseNotes) template.FuncMap {
+	return template.FuncMap{
+		"getSection": func(sections []Section, name string) *Section {
+			for i := range sections {
+				if sections[i].Name == name {
+					return &sections[i]
+				}
+			}
+			return nil
+		},
+		"timefmt": func(t time.Time, layout string) string {
+			return t.Format(layout)
+		},
+		"commitURL": func(hash string) string {
+			if notes.CommitURLFunc == nil {
+				return hash
+			}
+			return notes.CommitURLFunc(hash)
+		},
+		"compareURL": func(prev, next string) string {
+			if notes.CompareURLFunc == nil {
+				return ""
+			}
+			return notes.CompareURLFunc(prev, next)
+		},
+		"authorLink": func(name string) string {
+			if notes.AuthorURLFunc == nil {
+				return name
+			}
+			return fmt.Sprintf("[%s](%s)", name, notes.AuthorURLFunc(name))
+		},
+	}
+}
+
+// Render executes tmplSource (or DefaultTemplate, if blank) against notes.
+func Render(notes ReleaseNotes, tmplSource string) (string, error) {
+	if strings.TrimSpace(tmplSource) == "" {
+		tmplSource = DefaultTemplate
+	}
+
+	tmpl, err := template.New("release-notes").Funcs(funcMap(notes)).Parse(tmplSource)
+	if err != nil {
+		return "", fmt.Errorf("parsing release notes template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, notes); err != nil {
+		return "", fmt.Errorf("rendering release notes: %w", err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// RenderJSON marshals notes as indented JSON, for --notes-format json.
+func RenderJSON(notes ReleaseNotes) (string, error) {
+	out, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshalling release notes: %w", err)
+	}
+	return string(out), nil
+}
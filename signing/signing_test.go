@@ -0,0 +1,70 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestRepo(t *testing.T) (*git.Repository, object.Signature) {
+	t.Helper()
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	f, err := wt.Filesystem.Create("README.md")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("hello\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	_, err = wt.Add("README.md")
+	require.NoError(t, err)
+
+	tagger := object.Signature{Name: "Test", Email: "test@example.com", When: time.Now()}
+	_, err = wt.Commit("initial commit", &git.CommitOptions{Author: &tagger})
+	require.NoError(t, err)
+
+	return repo, tagger
+}
+
+func TestCreateTagAndVerify_PGP(t *testing.T) {
+	repo, tagger := newTestRepo(t)
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	entity, err := openpgp.NewEntity("Test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	key := &Key{pgp: entity}
+
+	_, err = CreateTag(repo, "v1.0.0", head.Hash(), tagger, "release v1.0.0", key)
+	require.NoError(t, err)
+
+	require.NoError(t, Verify(repo, "v1.0.0", key))
+}
+
+func TestCreateTagAndVerify_SSH(t *testing.T) {
+	repo, tagger := newTestRepo(t)
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	_, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromSigner(priv)
+	require.NoError(t, err)
+	key := &Key{ssh: signer}
+
+	_, err = CreateTag(repo, "v1.0.0", head.Hash(), tagger, "release v1.0.0", key)
+	require.NoError(t, err)
+
+	require.NoError(t, Verify(repo, "v1.0.0", key))
+}
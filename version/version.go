@@ -0,0 +1,146 @@
+// Package version implements parsing, comparison and bumping of SemVer 2.0.0
+// version strings, including prerelease and build-metadata support.
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/heyvito/semver-releaser/conventional"
+	"golang.org/x/mod/semver"
+)
+
+// Version represents a parsed SemVer 2.0.0 version.
+type Version struct {
+	Major, Minor, Patch int
+	Prerelease          string
+	Build               string
+}
+
+// Parse parses a version string such as "v1.2.0-rc.1+sha.abcd123" into its
+// components. The leading "v" is optional.
+func Parse(v string) (Version, error) {
+	v = strings.TrimPrefix(v, "v")
+
+	build := ""
+	if idx := strings.IndexByte(v, '+'); idx >= 0 {
+		build = v[idx+1:]
+		v = v[:idx]
+	}
+
+	pre := ""
+	if idx := strings.IndexByte(v, '-'); idx >= 0 {
+		pre = v[idx+1:]
+		v = v[:idx]
+	}
+
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q", v)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid major component in %q: %w", v, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid minor component in %q: %w", v, err)
+	}
+	patch, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid patch component in %q: %w", v, err)
+	}
+
+	return Version{Major: major, Minor: minor, Patch: patch, Prerelease: pre, Build: build}, nil
+}
+
+// String renders the version back into its canonical "vMAJOR.MINOR.PATCH"
+// form, with optional prerelease and build-metadata suffixes.
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare orders two versions per SemVer 2.0.0 precedence rules (numeric
+// identifiers compare numerically, alphanumeric ones lexically, and
+// prereleases always rank below their associated release). Build metadata
+// is ignored, as mandated by the spec.
+func (v Version) Compare(other Version) int {
+	return semver.Compare(v.String(), other.String())
+}
+
+// BumpOptions configures how Bump derives the next version.
+type BumpOptions struct {
+	// PreRelease, when set, marks the next version as a prerelease using
+	// this identifier (e.g. "rc", "beta").
+	PreRelease string
+	// Build, when set, is attached to the next version as build metadata.
+	Build string
+}
+
+// Bump computes the next version given the current one and the SemVer
+// component that should be bumped. If the current version is already a
+// prerelease on the same track requested by opts.PreRelease, only the
+// prerelease counter is incremented instead of the MAJOR/MINOR/PATCH triple.
+func Bump(current Version, kind conventional.SemVerComponent, opts BumpOptions) Version {
+	next := current
+
+	if opts.PreRelease != "" && current.Prerelease != "" && prereleaseTrack(current.Prerelease) == opts.PreRelease {
+		next.Prerelease = bumpPrerelease(current.Prerelease)
+		next.Build = opts.Build
+		return next
+	}
+
+	switch kind {
+	case conventional.SemVerPatch:
+		next.Patch++
+	case conventional.SemVerMinor:
+		next.Minor++
+		next.Patch = 0
+	case conventional.SemVerMajor:
+		next.Major++
+		next.Minor = 0
+		next.Patch = 0
+	}
+
+	if opts.PreRelease != "" {
+		next.Prerelease = opts.PreRelease + ".1"
+	} else {
+		next.Prerelease = ""
+	}
+	next.Build = opts.Build
+	return next
+}
+
+// prereleaseTrack strips a trailing numeric counter off a prerelease
+// identifier, e.g. "rc.3" -> "rc".
+func prereleaseTrack(p string) string {
+	idx := strings.LastIndexByte(p, '.')
+	if idx < 0 {
+		return p
+	}
+	if _, err := strconv.Atoi(p[idx+1:]); err != nil {
+		return p
+	}
+	return p[:idx]
+}
+
+func bumpPrerelease(p string) string {
+	idx := strings.LastIndexByte(p, '.')
+	if idx < 0 {
+		return p + ".1"
+	}
+	n, err := strconv.Atoi(p[idx+1:])
+	if err != nil {
+		return p + ".1"
+	}
+	return fmt.Sprintf("%s.%d", p[:idx], n+1)
+}
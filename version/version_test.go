@@ -0,0 +1,58 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/heyvito/semver-releaser/conventional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.2.3-rc.1+sha.abcd123")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Build: "sha.abcd123"}, v)
+
+	v, err = Parse("2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 2, Minor: 0, Patch: 0}, v)
+
+	_, err = Parse("not-a-version")
+	assert.Error(t, err)
+}
+
+func TestString(t *testing.T) {
+	v := Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Build: "sha.abcd123"}
+	assert.Equal(t, "v1.2.3-rc.1+sha.abcd123", v.String())
+	assert.Equal(t, "v1.0.0", Version{Major: 1}.String())
+}
+
+func TestCompare(t *testing.T) {
+	assert.Equal(t, -1, Version{Major: 1}.Compare(Version{Major: 2}))
+	assert.Equal(t, 1, Version{Major: 2}.Compare(Version{Major: 1}))
+	assert.Equal(t, 0, Version{Major: 1, Minor: 2, Patch: 3}.Compare(Version{Major: 1, Minor: 2, Patch: 3}))
+	assert.Equal(t, -1, Version{Major: 1, Prerelease: "rc.1"}.Compare(Version{Major: 1}))
+}
+
+func TestBump(t *testing.T) {
+	current := Version{Major: 1, Minor: 2, Patch: 3}
+
+	assert.Equal(t, Version{Major: 1, Minor: 2, Patch: 4}, Bump(current, conventional.SemVerPatch, BumpOptions{}))
+	assert.Equal(t, Version{Major: 1, Minor: 3, Patch: 0}, Bump(current, conventional.SemVerMinor, BumpOptions{}))
+	assert.Equal(t, Version{Major: 2, Minor: 0, Patch: 0}, Bump(current, conventional.SemVerMajor, BumpOptions{}))
+}
+
+func TestBump_PreRelease(t *testing.T) {
+	current := Version{Major: 1, Minor: 2, Patch: 3}
+	next := Bump(current, conventional.SemVerMinor, BumpOptions{PreRelease: "rc"})
+	assert.Equal(t, Version{Major: 1, Minor: 3, Patch: 0, Prerelease: "rc.1"}, next)
+
+	again := Bump(next, conventional.SemVerMinor, BumpOptions{PreRelease: "rc"})
+	assert.Equal(t, Version{Major: 1, Minor: 3, Patch: 0, Prerelease: "rc.2"}, again)
+}
+
+func TestBump_Build(t *testing.T) {
+	current := Version{Major: 1, Minor: 2, Patch: 3}
+	next := Bump(current, conventional.SemVerPatch, BumpOptions{Build: "sha.deadbeef"})
+	assert.Equal(t, "sha.deadbeef", next.Build)
+}